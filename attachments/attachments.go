@@ -0,0 +1,142 @@
+// Package attachments stores article file uploads in MongoDB GridFS and
+// tracks their metadata in the attachments collection.
+package attachments
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+)
+
+// MaxSize is the largest attachment accepted, enforced via http.MaxBytesReader.
+const MaxSize = 10 << 20 // 10 MiB
+
+// AllowedContentTypes is the allow-list checked against the upload's
+// Content-Type before it is accepted.
+var AllowedContentTypes = map[string]bool{
+	"image/jpeg":      true,
+	"image/png":       true,
+	"application/pdf": true,
+}
+
+// Attachment is the metadata record stored alongside the GridFS file.
+type Attachment struct {
+	ID          string    `json:"_id,omitempty" bson:"_id,omitempty"`
+	ArticleID   string    `json:"article_id" bson:"article_id"`
+	Filename    string    `json:"filename" bson:"filename"`
+	ContentType string    `json:"content_type" bson:"content_type"`
+	Size        int64     `json:"size" bson:"size"`
+	Checksum    string    `json:"checksum" bson:"checksum"`
+	UploadedAt  time.Time `json:"uploaded_at" bson:"uploaded_at"`
+}
+
+// Store bundles the GridFS bucket and metadata collection used to manage
+// attachments on the articles database.
+type Store struct {
+	bucket     *gridfs.Bucket
+	collection *mongo.Collection
+	articles   *mongo.Collection
+}
+
+// NewStore opens the attachments GridFS bucket and metadata collection. It
+// also holds a reference to the articles collection (same name store.
+// NewArticles uses) so uploads and deletes can keep Article.Attachments in
+// sync; it can't import package store directly, since store already
+// imports attachments for the Attachment type.
+func NewStore(client *mongo.Client) (*Store, error) {
+	db := client.Database("articles")
+	bucket, err := gridfs.NewBucket(db)
+	if err != nil {
+		return nil, err
+	}
+	return &Store{bucket: bucket, collection: db.Collection("attachments"), articles: db.Collection("go")}, nil
+}
+
+// Upload streams file into GridFS, computing its SHA-256 checksum as it
+// goes, then persists an Attachment record referencing articleID and
+// appends it to that article's Attachments so Get/List/GetMany (and the
+// GraphQL DataLoader built on top of them) see it without a second query.
+func (s *Store) Upload(ctx context.Context, articleID, filename, contentType string, file io.Reader) (*Attachment, error) {
+	hasher := sha256.New()
+	uploadStream, err := s.bucket.OpenUploadStream(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer uploadStream.Close()
+
+	size, err := io.Copy(uploadStream, io.TeeReader(file, hasher))
+	if err != nil {
+		return nil, err
+	}
+
+	fileID, ok := uploadStream.FileID.(primitive.ObjectID)
+	if !ok {
+		return nil, mongo.ErrClientDisconnected
+	}
+
+	attachment := Attachment{
+		ID:          fileID.Hex(),
+		ArticleID:   articleID,
+		Filename:    filename,
+		ContentType: contentType,
+		Size:        size,
+		Checksum:    hex.EncodeToString(hasher.Sum(nil)),
+		UploadedAt:  time.Now(),
+	}
+
+	if _, err := s.collection.InsertOne(ctx, attachment); err != nil {
+		return nil, err
+	}
+	if _, err := s.articles.UpdateOne(ctx, bson.M{"_id": articleID}, bson.M{"$push": bson.M{"attachments": attachment}}); err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+// Metadata looks up the Attachment record for a GridFS file id.
+func (s *Store) Metadata(ctx context.Context, fileID string) (*Attachment, error) {
+	var attachment Attachment
+	err := s.collection.FindOne(ctx, bson.M{"_id": fileID}).Decode(&attachment)
+	if err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+// Download streams a GridFS file's bytes to w.
+func (s *Store) Download(ctx context.Context, fileID string, w io.Writer) error {
+	objID, err := primitive.ObjectIDFromHex(fileID)
+	if err != nil {
+		return err
+	}
+	_, err = s.bucket.DownloadToStream(objID, w)
+	return err
+}
+
+// Delete removes the GridFS file, its metadata record, and its entry in the
+// owning article's Attachments.
+func (s *Store) Delete(ctx context.Context, fileID string) error {
+	objID, err := primitive.ObjectIDFromHex(fileID)
+	if err != nil {
+		return err
+	}
+	attachment, err := s.Metadata(ctx, fileID)
+	if err != nil {
+		return err
+	}
+	if err := s.bucket.Delete(objID); err != nil {
+		return err
+	}
+	if _, err := s.collection.DeleteOne(ctx, bson.M{"_id": fileID}); err != nil {
+		return err
+	}
+	_, err = s.articles.UpdateOne(ctx, bson.M{"_id": attachment.ArticleID}, bson.M{"$pull": bson.M{"attachments": bson.M{"_id": fileID}}})
+	return err
+}
@@ -0,0 +1,63 @@
+package attachments
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+// mockUploadResponses satisfies, in order, the commands GridFS issues the
+// first time a bucket is written to (an empty-check FindOne, a listIndexes
+// + createIndexes pair for each of fs.files and fs.chunks, then the chunk
+// and file inserts), followed by the attachments metadata insert and the
+// article $push update that Upload adds on top.
+func mockUploadResponses(mt *mtest.T) {
+	mt.AddMockResponses(
+		mtest.CreateCursorResponse(0, "articles.fs.files", mtest.FirstBatch),
+		mtest.CreateCursorResponse(0, "articles.fs.files", mtest.FirstBatch),
+		mtest.CreateSuccessResponse(),
+		mtest.CreateCursorResponse(0, "articles.fs.chunks", mtest.FirstBatch),
+		mtest.CreateSuccessResponse(),
+		mtest.CreateSuccessResponse(), // chunk insert
+		mtest.CreateSuccessResponse(), // file insert
+		mtest.CreateSuccessResponse(), // attachments metadata insert
+		mtest.CreateSuccessResponse(), // article $push update
+	)
+}
+
+// TestUploadSyncsArticleAttachments guards against the bug where uploads
+// only ever landed in the attachments collection: Article.Attachments (and
+// therefore the GraphQL DataLoader and GET /article/{id}, both of which
+// read it straight off the article document) would stay empty no matter
+// how many files were uploaded.
+func TestUploadSyncsArticleAttachments(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	mt.Run("upload pushes onto the article", func(mt *mtest.T) {
+		mockUploadResponses(mt)
+
+		store, err := NewStore(mt.Client)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		att, err := store.Upload(context.Background(), "article-1", "test.txt", "text/plain", strings.NewReader("hello"))
+		if err != nil {
+			t.Fatalf("Upload: %v", err)
+		}
+
+		var updates []string
+		for _, e := range mt.GetAllStartedEvents() {
+			if e.CommandName == "update" {
+				updates = append(updates, e.Command.String())
+			}
+		}
+		if len(updates) != 1 {
+			t.Fatalf("expected exactly 1 update command (the article $push), got %d: %v", len(updates), updates)
+		}
+		if !strings.Contains(updates[0], "$push") || !strings.Contains(updates[0], att.ID) {
+			t.Fatalf("expected article update to $push the new attachment %q, got %s", att.ID, updates[0])
+		}
+	})
+}
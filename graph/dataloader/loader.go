@@ -0,0 +1,87 @@
+// Package dataloader batches the per-article attachment lookups that the
+// GraphQL articles(...) query would otherwise issue one by one.
+package dataloader
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/KamillaKa/my-go-api/attachments"
+	"github.com/KamillaKa/my-go-api/store"
+)
+
+type contextKey string
+
+const loadersKey contextKey = "dataloaders"
+
+// waitDuration batches requests that arrive within this window of the first one.
+const waitDuration = 2 * time.Millisecond
+
+// Loaders bundles the per-request batched loaders available to resolvers.
+type Loaders struct {
+	Attachments *AttachmentLoader
+}
+
+// AttachmentLoader batches Article.attachments lookups across a single
+// GraphQL response so N articles cost one Mongo query instead of N.
+type AttachmentLoader struct {
+	articles *store.Articles
+
+	mu      sync.Mutex
+	batch   []string
+	waiting []chan []attachments.Attachment
+	timer   *time.Timer
+}
+
+// Middleware attaches a fresh, per-request set of loaders to the context so
+// gqlgen resolvers can share a batch window across one GraphQL operation.
+func Middleware(articles *store.Articles) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			loaders := &Loaders{Attachments: &AttachmentLoader{articles: articles}}
+			ctx := context.WithValue(r.Context(), loadersKey, loaders)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// For retrieves the request-scoped Loaders, if any.
+func For(ctx context.Context) *Loaders {
+	loaders, _ := ctx.Value(loadersKey).(*Loaders)
+	return loaders
+}
+
+// Load queues articleID for the in-flight batch and blocks until that
+// batch's single Mongo query resolves.
+func (l *AttachmentLoader) Load(ctx context.Context, articleID string) ([]attachments.Attachment, error) {
+	l.mu.Lock()
+	ch := make(chan []attachments.Attachment, 1)
+	l.batch = append(l.batch, articleID)
+	l.waiting = append(l.waiting, ch)
+	if l.timer == nil {
+		l.timer = time.AfterFunc(waitDuration, func() { l.dispatch(ctx) })
+	}
+	l.mu.Unlock()
+
+	return <-ch, nil
+}
+
+func (l *AttachmentLoader) dispatch(ctx context.Context) {
+	l.mu.Lock()
+	ids, waiting := l.batch, l.waiting
+	l.batch, l.waiting, l.timer = nil, nil, nil
+	l.mu.Unlock()
+
+	byID := make(map[string][]attachments.Attachment, len(ids))
+	if found, err := l.articles.GetMany(ctx, ids); err == nil {
+		for _, article := range found {
+			byID[article.ID] = article.Attachments
+		}
+	}
+
+	for i, id := range ids {
+		waiting[i] <- byID[id]
+	}
+}
@@ -0,0 +1,25 @@
+package graph
+
+import (
+	"github.com/KamillaKa/my-go-api/attachments"
+	"github.com/KamillaKa/my-go-api/store"
+)
+
+//go:generate go run github.com/99designs/gqlgen generate
+
+// This file will not be regenerated automatically.
+//
+// It serves as dependency injection for your app, add any dependencies you
+// require here.
+
+// Resolver is the root resolver; its fields are the dependencies shared by
+// every query and mutation resolver. It implements generated.ResolverRoot.
+//
+// These are named ArticleStore/AttachmentStore rather than Articles/
+// Attachments because queryResolver.Articles and articleResolver.Attachments
+// are methods on structs that embed *Resolver: a same-named field at a
+// greater embedding depth would be shadowed by the method, not promoted.
+type Resolver struct {
+	ArticleStore    *store.Articles
+	AttachmentStore *attachments.Store
+}
@@ -0,0 +1,100 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.45
+
+import (
+	"context"
+
+	"github.com/KamillaKa/my-go-api/graph/dataloader"
+	"github.com/KamillaKa/my-go-api/graph/generated"
+	"github.com/KamillaKa/my-go-api/graph/model"
+	"github.com/KamillaKa/my-go-api/store"
+)
+
+// Articles is the resolver for the articles field.
+func (r *queryResolver) Articles(ctx context.Context, filter *model.ArticleFilter, sort *model.ArticleSort, page *int, limit *int) ([]*store.Article, error) {
+	params := store.ListParams{}
+	if filter != nil {
+		if filter.Title != nil {
+			params.Title = *filter.Title
+		}
+		if filter.Desc != nil {
+			params.Desc = *filter.Desc
+		}
+	}
+	if sort != nil {
+		params.SortField = sort.Field
+		params.SortOrder = sort.Order
+	}
+	if page != nil {
+		params.Page = *page
+	}
+	if limit != nil {
+		params.Limit = *limit
+	}
+
+	articles, err := r.ArticleStore.List(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*store.Article, len(articles))
+	for i := range articles {
+		result[i] = &articles[i]
+	}
+	return result, nil
+}
+
+// Article is the resolver for the article field.
+func (r *queryResolver) Article(ctx context.Context, id string) (*store.Article, error) {
+	return r.ArticleStore.Get(ctx, id)
+}
+
+// CreateArticle is the resolver for the createArticle field.
+func (r *mutationResolver) CreateArticle(ctx context.Context, input model.ArticleInput) (*store.Article, error) {
+	return r.ArticleStore.Create(ctx, store.Article{Title: input.Title, Desc: input.Desc, Content: input.Content})
+}
+
+// UpdateArticle is the resolver for the updateArticle field.
+func (r *mutationResolver) UpdateArticle(ctx context.Context, id string, input model.ArticleInput) (*store.Article, error) {
+	return r.ArticleStore.Update(ctx, id, store.Article{Title: input.Title, Desc: input.Desc, Content: input.Content})
+}
+
+// DeleteArticle is the resolver for the deleteArticle field.
+func (r *mutationResolver) DeleteArticle(ctx context.Context, id string) (bool, error) {
+	if err := r.ArticleStore.Delete(ctx, id); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Attachments is the resolver for the attachments field, served through the
+// per-request DataLoader so selecting it across many articles costs one
+// Mongo query instead of one per article.
+func (r *articleResolver) Attachments(ctx context.Context, obj *store.Article) ([]*model.Attachment, error) {
+	loaded, err := dataloader.For(ctx).Attachments.Load(ctx, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.Attachment, len(loaded))
+	for i, a := range loaded {
+		result[i] = &model.Attachment{ID: a.ID, Filename: a.Filename, ContentType: a.ContentType, Size: int(a.Size)}
+	}
+	return result, nil
+}
+
+// Query returns generated.QueryResolver implementation.
+func (r *Resolver) Query() generated.QueryResolver { return &queryResolver{r} }
+
+// Mutation returns generated.MutationResolver implementation.
+func (r *Resolver) Mutation() generated.MutationResolver { return &mutationResolver{r} }
+
+// Article returns generated.ArticleResolver implementation.
+func (r *Resolver) Article() generated.ArticleResolver { return &articleResolver{r} }
+
+type queryResolver struct{ *Resolver }
+type mutationResolver struct{ *Resolver }
+type articleResolver struct{ *Resolver }
@@ -0,0 +1,26 @@
+package graph
+
+import (
+	"net/http"
+
+	"github.com/99designs/gqlgen/graphql/handler"
+
+	"github.com/KamillaKa/my-go-api/attachments"
+	"github.com/KamillaKa/my-go-api/graph/dataloader"
+	"github.com/KamillaKa/my-go-api/graph/generated"
+	"github.com/KamillaKa/my-go-api/store"
+)
+
+// NewHandler builds the /graphql endpoint backed by the given stores,
+// running every request through gqlgen's generated executable schema so the
+// document is validated and linked against schema.graphqls before any
+// resolver runs. It attaches a fresh attachments DataLoader to each request
+// (see graph/dataloader) so that selecting Article.attachments across a
+// page of results costs one Mongo query instead of one per article.
+func NewHandler(articles *store.Articles, attachmentStore *attachments.Store) http.Handler {
+	resolver := &Resolver{ArticleStore: articles, AttachmentStore: attachmentStore}
+	schema := generated.NewExecutableSchema(generated.Config{Resolvers: resolver})
+
+	srv := handler.NewDefaultServer(schema)
+	return dataloader.Middleware(articles)(srv)
+}
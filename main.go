@@ -3,27 +3,115 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 	"os"
+	"os/signal"
 	"strconv"
+	"syscall"
 	"time"
 
+	"github.com/99designs/gqlgen/graphql/playground"
 	"github.com/gorilla/mux"
 	"github.com/joho/godotenv"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/KamillaKa/my-go-api/attachments"
+	"github.com/KamillaKa/my-go-api/graph"
+	"github.com/KamillaKa/my-go-api/middleware/jwt"
+	"github.com/KamillaKa/my-go-api/middleware/logging"
+	"github.com/KamillaKa/my-go-api/pkg/config"
+	"github.com/KamillaKa/my-go-api/pkg/logger"
+	"github.com/KamillaKa/my-go-api/search"
+	"github.com/KamillaKa/my-go-api/store"
+	"github.com/KamillaKa/my-go-api/users"
+	"github.com/KamillaKa/my-go-api/ws"
 )
 
 var client *mongo.Client
 
-type Article struct {
-	ID      string `json:"_id,omitempty" bson:"_id,omitempty"`
-	Title   string `json:"Title" bson:"title"`
-	Desc    string `json:"desc" bson:"desc"`
-	Content string `json:"content" bson:"content"`
+// log is the structured logger used throughout the service; configured in
+// main from cfg.Log and passed to the request logging middleware.
+var log *slog.Logger
+
+// articleStore is the shared CRUD path used by both the REST handlers below
+// and the GraphQL resolvers in graph.
+var articleStore *store.Articles
+
+// searchClient is nil when ES_URL is unset, in which case search falls back
+// to the Mongo title regex scan in regexSearchFallback.
+var searchClient *search.Client
+
+// hub fans newly created articles out to subscribers of GET /ws/articles.
+var hub = ws.NewHub()
+
+// attachmentStore backs the article attachment upload/download/delete endpoints.
+var attachmentStore *attachments.Store
+
+type credentials struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+func register(w http.ResponseWriter, r *http.Request) {
+	var creds credentials
+	if err := json.NewDecoder(r.Body).Decode(&creds); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Invalid request body")
+		return
+	}
+
+	ctx, cancel := users.WithTimeout()
+	defer cancel()
+
+	user, err := users.Create(ctx, users.Collection(client), creds.Email, creds.Password)
+	switch {
+	case errors.Is(err, users.ErrInvalidCredentials):
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	case errors.Is(err, users.ErrEmailTaken):
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	case err != nil:
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Error creating user")
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(user)
+}
+
+func login(w http.ResponseWriter, r *http.Request) {
+	var creds credentials
+	if err := json.NewDecoder(r.Body).Decode(&creds); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Invalid request body")
+		return
+	}
+
+	ctx, cancel := users.WithTimeout()
+	defer cancel()
+
+	user, err := users.FindByEmail(ctx, users.Collection(client), creds.Email)
+	if err != nil || !user.CheckPassword(creds.Password) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode("Invalid email or password")
+		return
+	}
+
+	token, err := jwt.Generate(user.ID)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Error generating token")
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]string{"token": token})
 }
 
 func homePage(w http.ResponseWriter, r *http.Request) {
@@ -37,7 +125,7 @@ func initializeMockData() {
 	defer cancel()
 
 	// Mock articles
-	mockArticles := []Article{
+	mockArticles := []store.Article{
 		{Title: "The Rise of AI", Desc: "Exploring the advancements in Artificial Intelligence.", Content: "Artificial Intelligence (AI) has made significant strides in recent years, impacting industries and everyday life."},
 		{Title: "Understanding Blockchain Technology", Desc: "A deep dive into how blockchain works.", Content: "Blockchain technology underpins cryptocurrencies like Bitcoin and has potential applications across various sectors."},
 		{Title: "Climate Change: The Facts", Desc: "Discussing the realities of climate change and its impacts.", Content: "Climate change poses a significant threat to our planet, with rising temperatures and extreme weather events becoming more common."},
@@ -53,122 +141,147 @@ func initializeMockData() {
 	// Check if the collection is empty before inserting mock data
 	count, err := collection.CountDocuments(ctx, bson.M{})
 	if err != nil {
-		log.Fatal(err)
+		log.Error("counting articles", "error", err)
+		os.Exit(1)
 	}
 
 	if count == 0 {
 		// Insert mock articles into the collection
-		_, err = collection.InsertMany(ctx, mockArticles)
+		docs := make([]interface{}, len(mockArticles))
+		for i, article := range mockArticles {
+			docs[i] = article
+		}
+		_, err = collection.InsertMany(ctx, docs)
 		if err != nil {
-			log.Fatal(err)
+			log.Error("inserting mock articles", "error", err)
+			os.Exit(1)
 		}
-		fmt.Println("Mock data inserted successfully!")
+		log.Info("mock data inserted successfully")
 	} else {
-		fmt.Println("Mock data already exists, skipping insertion.")
+		log.Info("mock data already exists, skipping insertion")
 	}
 }
 
 // Filtering, Sorting, and Pagination for retrieving all articles
 func returnAllArticles(w http.ResponseWriter, r *http.Request) {
-	collection := client.Database("articles").Collection("go")
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	// Extracting query parameters for filtering, sorting, and pagination
-	queryTitle := r.URL.Query().Get("title") // Filter by title
-	queryDesc := r.URL.Query().Get("desc")   // Filter by description
-	sortField := r.URL.Query().Get("sort")   // Sort by field (e.g., title, desc)
-	sortOrder := r.URL.Query().Get("order")  // Sort order: 1 for ascending, -1 for descending
-	pageStr := r.URL.Query().Get("page")     // Page number
-	limitStr := r.URL.Query().Get("limit")   // Limit of items per page
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	order, _ := strconv.Atoi(r.URL.Query().Get("order"))
 
-	// Set defaults if pagination params are not provided
-	page, _ := strconv.Atoi(pageStr)
-	limit, _ := strconv.Atoi(limitStr)
-	if page <= 0 {
-		page = 1
-	}
-	if limit <= 0 {
-		limit = 10 // Default limit is 10
+	articles, err := articleStore.List(ctx, store.ListParams{
+		Title:     r.URL.Query().Get("title"),
+		Desc:      r.URL.Query().Get("desc"),
+		SortField: r.URL.Query().Get("sort"),
+		SortOrder: order,
+		Page:      page,
+		Limit:     limit,
+	})
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Error fetching articles")
+		return
 	}
-	skip := (page - 1) * limit // Calculate the offset for pagination
 
-	// Build MongoDB filter
-	filter := bson.M{}
-	if queryTitle != "" {
-		filter["title"] = bson.M{"$regex": queryTitle, "$options": "i"} // Case-insensitive filtering
-	}
-	if queryDesc != "" {
-		filter["desc"] = bson.M{"$regex": queryDesc, "$options": "i"}
-	}
+	json.NewEncoder(w).Encode(articles)
+}
 
-	// Sorting logic
-	sortOptions := options.Find()
-	if sortField != "" {
-		order, _ := strconv.Atoi(sortOrder)
-		if order != 1 && order != -1 {
-			order = 1 // Default to ascending
-		}
-		sortOptions.SetSort(bson.D{{sortField, order}})
+func createNewArticle(w http.ResponseWriter, r *http.Request) {
+	var article store.Article
+	_ = json.NewDecoder(r.Body).Decode(&article)
+
+	if userID, ok := jwt.UserID(r.Context()); ok {
+		article.AuthorID = userID
 	}
 
-	// Pagination logic
-	sortOptions.SetSkip(int64(skip))
-	sortOptions.SetLimit(int64(limit))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
 
-	// Execute query with filtering, sorting, and pagination
-	var articles []Article
-	cursor, err := collection.Find(ctx, filter, sortOptions)
+	created, err := articleStore.Create(ctx, article)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode("Error fetching articles")
+		json.NewEncoder(w).Encode("Error creating article")
 		return
 	}
-	defer cursor.Close(ctx)
 
-	for cursor.Next(ctx) {
-		var article Article
-		cursor.Decode(&article)
-		articles = append(articles, article)
+	if searchClient != nil {
+		doc := search.Document{ID: created.ID, Title: created.Title, Desc: created.Desc, Content: created.Content}
+		if err := searchClient.Index(ctx, doc); err != nil {
+			log.Error("indexing article", "article_id", created.ID, "error", err)
+		}
 	}
-	if err := cursor.Err(); err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode("Error iterating articles")
-		return
+
+	if payload, err := json.Marshal(created); err == nil {
+		hub.Publish(created.Title, payload)
 	}
 
-	// Send response with articles
-	json.NewEncoder(w).Encode(articles)
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(created)
 }
 
-func createNewArticle(w http.ResponseWriter, r *http.Request) {
-	var article Article
-	_ = json.NewDecoder(r.Body).Decode(&article)
+func returnSearchArticles(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query().Get("q")
+	from, _ := strconv.Atoi(r.URL.Query().Get("from"))
+	size, _ := strconv.Atoi(r.URL.Query().Get("size"))
+	if size <= 0 {
+		size = 10
+	}
 
-	collection := client.Database("articles").Collection("go")
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	_, err := collection.InsertOne(ctx, article)
+	if searchClient == nil {
+		resp, err := regexSearchFallback(ctx, q, from, size)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode("Error searching articles")
+			return
+		}
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+
+	resp, err := searchClient.Search(ctx, q, from, size)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode("Error creating article")
+		json.NewEncoder(w).Encode("Error searching articles")
 		return
 	}
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(article)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// regexSearchFallback serves /articles/search off the Mongo title regex scan
+// when Elasticsearch (ES_URL) isn't configured, so search keeps working
+// instead of going unavailable.
+func regexSearchFallback(ctx context.Context, q string, from, size int) (*search.Response, error) {
+	articles, err := articleStore.List(ctx, store.ListParams{
+		Title: q,
+		Page:  from/size + 1,
+		Limit: size,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &search.Response{Total: int64(len(articles))}
+	for _, article := range articles {
+		resp.Hits = append(resp.Hits, search.Result{
+			Document: search.Document{ID: article.ID, Title: article.Title, Desc: article.Desc, Content: article.Content},
+		})
+	}
+	return resp, nil
 }
 
 func returnSingleArticle(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id := vars["id"]
 
-	collection := client.Database("articles").Collection("go")
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	var article Article
-	err := collection.FindOne(ctx, bson.M{"_id": id}).Decode(&article)
+	article, err := articleStore.Get(ctx, id)
 	if err != nil {
 		w.WriteHeader(http.StatusNotFound)
 		json.NewEncoder(w).Encode("Article not found")
@@ -177,41 +290,181 @@ func returnSingleArticle(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(article)
 }
 
-func handleRequests() {
+func uploadAttachment(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	articleID := vars["id"]
+
+	r.Body = http.MaxBytesReader(w, r.Body, attachments.MaxSize)
+	if err := r.ParseMultipartForm(attachments.MaxSize); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("File too large or malformed upload")
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Missing file field")
+		return
+	}
+	defer file.Close()
+
+	contentType := header.Header.Get("Content-Type")
+	if !attachments.AllowedContentTypes[contentType] {
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+		json.NewEncoder(w).Encode("Unsupported content type")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	attachment, err := attachmentStore.Upload(ctx, articleID, header.Filename, contentType, file)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Error storing attachment")
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(attachment)
+}
+
+func downloadAttachment(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	fileID := vars["fileId"]
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	attachment, err := attachmentStore.Metadata(ctx, fileID)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode("Attachment not found")
+		return
+	}
+
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", attachment.Filename))
+	if err := attachmentStore.Download(ctx, fileID, w); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Error streaming attachment")
+		return
+	}
+}
+
+func deleteAttachment(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	fileID := vars["fileId"]
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := attachmentStore.Delete(ctx, fileID); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode("Error deleting attachment")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func handleRequests(cfg config.HTTP) *http.Server {
 	router := mux.NewRouter().StrictSlash(true)
+	router.Use(logging.Middleware(log))
 	router.HandleFunc("/", homePage)
 	router.HandleFunc("/articles", returnAllArticles).Methods("GET")
-	router.HandleFunc("/article", createNewArticle).Methods("POST")
+	router.HandleFunc("/articles/search", returnSearchArticles).Methods("GET")
 	router.HandleFunc("/article/{id}", returnSingleArticle).Methods("GET")
-	log.Fatal(http.ListenAndServe(":10000", router))
+	router.HandleFunc("/register", register).Methods("POST")
+	router.HandleFunc("/login", login).Methods("POST")
+	router.HandleFunc("/ws/articles", hub.ServeWS).Methods("GET")
+	router.HandleFunc("/article/{id}/attachments", uploadAttachment).Methods("POST")
+	router.HandleFunc("/attachments/{fileId}", downloadAttachment).Methods("GET")
+	router.Handle("/graphql", graph.NewHandler(articleStore, attachmentStore)).Methods("POST")
+	router.Handle("/playground", playground.Handler("GraphQL playground", "/graphql")).Methods("GET")
+
+	// Creating an article and deleting an attachment require a valid Bearer
+	// token. Attachment uploads and /graphql (including its mutations) are
+	// registered on the router above and are not token-protected.
+	protected := router.PathPrefix("").Subrouter()
+	protected.Use(jwt.Middleware)
+	protected.HandleFunc("/article", createNewArticle).Methods("POST")
+	protected.HandleFunc("/attachments/{fileId}", deleteAttachment).Methods("DELETE")
+
+	return &http.Server{
+		Addr:         cfg.Addr,
+		Handler:      router,
+		ReadTimeout:  cfg.ReadTimeout,
+		WriteTimeout: cfg.WriteTimeout,
+	}
 }
 
 func main() {
-	// Load environment variables from the .env file
-	err := godotenv.Load(".env")
+	// Load environment variables from the .env file, falling back to
+	// config.yaml / the process environment if it doesn't exist.
+	if err := godotenv.Load(".env"); err != nil {
+		fmt.Println("No .env file found, continuing with the process environment")
+	}
+
+	cfg, err := config.Load("config.yaml")
 	if err != nil {
-		log.Fatal("Error loading .env file")
+		fmt.Println("loading config:", err)
+		os.Exit(1)
 	}
+	log = logger.New(cfg.Log)
 
-	// MongoDB connection setup
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	connectCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
-	// Use DB_URL from .env
-	dbURI := os.Getenv("DB_URL")
-	if dbURI == "" {
-		log.Fatal("DB_URL not set in the environment")
+	client, err = mongo.Connect(connectCtx, options.Client().ApplyURI(cfg.Mongo.URI))
+	if err != nil {
+		log.Error("connecting to mongo", "error", err)
+		os.Exit(1)
 	}
 
-	client, err = mongo.Connect(ctx, options.Client().ApplyURI(dbURI))
+	articleStore = store.NewArticles(client)
+
+	// Elasticsearch is optional; search degrades to Mongo regex if ES_URL is unset.
+	searchClient, err = search.New(connectCtx)
 	if err != nil {
-		log.Fatal(err)
+		log.Error("connecting to elasticsearch", "error", err)
+		os.Exit(1)
+	}
+
+	attachmentStore, err = attachments.NewStore(client)
+	if err != nil {
+		log.Error("opening attachment store", "error", err)
+		os.Exit(1)
 	}
-	defer client.Disconnect(ctx)
 
 	// Initialize mock data
 	initializeMockData()
 
-	// Start the server
-	handleRequests()
+	// Start the article feed hub
+	go hub.Run()
+
+	server := handleRequests(cfg.HTTP)
+	go func() {
+		log.Info("starting server", "addr", cfg.HTTP.Addr)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error("server failed", "error", err)
+			os.Exit(1)
+		}
+	}()
+
+	<-ctx.Done()
+	log.Info("shutdown signal received, draining in-flight requests")
+
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), cfg.HTTP.ShutdownTimeout)
+	defer shutdownCancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Error("graceful shutdown failed", "error", err)
+	}
+	if err := client.Disconnect(shutdownCtx); err != nil {
+		log.Error("disconnecting from mongo", "error", err)
+	}
 }
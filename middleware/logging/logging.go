@@ -0,0 +1,71 @@
+// Package logging provides the HTTP middleware that assigns each request a
+// UUID, logs it on completion, and recovers from handler panics.
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type contextKey string
+
+const requestIDKey contextKey = "requestID"
+
+// RequestID returns the UUID assigned to the request, if any.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+// statusRecorder captures the status code written by the wrapped handler so
+// it can be logged after the fact.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Middleware assigns a request id, logs method/path/status/latency on
+// completion, and recovers from panics in the wrapped handler with a 500.
+func Middleware(log *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestID := uuid.NewString()
+			ctx := context.WithValue(r.Context(), requestIDKey, requestID)
+			r = r.WithContext(ctx)
+
+			rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			start := time.Now()
+
+			defer func() {
+				if err := recover(); err != nil {
+					log.Error("panic recovered",
+						"request_id", requestID,
+						"method", r.Method,
+						"path", r.URL.Path,
+						"error", err,
+					)
+					rec.WriteHeader(http.StatusInternalServerError)
+				}
+
+				log.Info("request",
+					"request_id", requestID,
+					"method", r.Method,
+					"path", r.URL.Path,
+					"status", rec.status,
+					"latency_ms", time.Since(start).Milliseconds(),
+				)
+			}()
+
+			next.ServeHTTP(rec, r)
+		})
+	}
+}
@@ -0,0 +1,114 @@
+// Package config loads the service configuration from config.yaml, with
+// environment variables taking precedence over file values.
+package config
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// HTTP holds the listener and timeout settings for the API server.
+type HTTP struct {
+	Addr            string        `yaml:"addr"`
+	ReadTimeout     time.Duration `yaml:"read_timeout"`
+	WriteTimeout    time.Duration `yaml:"write_timeout"`
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+}
+
+// UnmarshalYAML lets config.yaml express timeouts as duration strings
+// (e.g. "10s") while keeping the Go-side fields as time.Duration.
+func (h *HTTP) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var raw struct {
+		Addr            string `yaml:"addr"`
+		ReadTimeout     string `yaml:"read_timeout"`
+		WriteTimeout    string `yaml:"write_timeout"`
+		ShutdownTimeout string `yaml:"shutdown_timeout"`
+	}
+	if err := unmarshal(&raw); err != nil {
+		return err
+	}
+
+	h.Addr = raw.Addr
+	var err error
+	if h.ReadTimeout, err = time.ParseDuration(raw.ReadTimeout); err != nil {
+		return fmt.Errorf("http.read_timeout: %w", err)
+	}
+	if h.WriteTimeout, err = time.ParseDuration(raw.WriteTimeout); err != nil {
+		return fmt.Errorf("http.write_timeout: %w", err)
+	}
+	if h.ShutdownTimeout, err = time.ParseDuration(raw.ShutdownTimeout); err != nil {
+		return fmt.Errorf("http.shutdown_timeout: %w", err)
+	}
+	return nil
+}
+
+// Mongo holds the MongoDB connection settings.
+type Mongo struct {
+	URI string `yaml:"uri"`
+	DB  string `yaml:"db"`
+}
+
+// Log holds the logger's verbosity and output format.
+type Log struct {
+	Level  string `yaml:"level"`
+	Format string `yaml:"format"`
+}
+
+// Config is the fully resolved service configuration.
+type Config struct {
+	HTTP  HTTP  `yaml:"http"`
+	Mongo Mongo `yaml:"mongo"`
+	Log   Log   `yaml:"log"`
+}
+
+// Load reads path (config.yaml), then overrides any field that has a
+// corresponding environment variable set, and validates the required
+// mongo.uri is present.
+func Load(path string) (*Config, error) {
+	cfg := &Config{}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading config file: %w", err)
+	}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("parsing config file: %w", err)
+	}
+
+	applyEnvOverrides(cfg)
+
+	if cfg.Mongo.URI == "" {
+		return nil, fmt.Errorf("mongo.uri is required (set DB_URL or mongo.uri in %s)", path)
+	}
+	return cfg, nil
+}
+
+// applyEnvOverrides layers well-known environment variables over whatever
+// config.yaml set: DB_URL, MONGO_DB, HTTP_ADDR, HTTP_SHUTDOWN_TIMEOUT,
+// LOG_LEVEL and LOG_FORMAT. AUTH_SECRET and ES_URL are not part of Config;
+// the jwt and search packages read them from the environment directly.
+func applyEnvOverrides(cfg *Config) {
+	if v := os.Getenv("DB_URL"); v != "" {
+		cfg.Mongo.URI = v
+	}
+	if v := os.Getenv("MONGO_DB"); v != "" {
+		cfg.Mongo.DB = v
+	}
+	if v := os.Getenv("HTTP_ADDR"); v != "" {
+		cfg.HTTP.Addr = v
+	}
+	if v := os.Getenv("HTTP_SHUTDOWN_TIMEOUT"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.HTTP.ShutdownTimeout = d
+		}
+	}
+	if v := os.Getenv("LOG_LEVEL"); v != "" {
+		cfg.Log.Level = v
+	}
+	if v := os.Getenv("LOG_FORMAT"); v != "" {
+		cfg.Log.Format = v
+	}
+}
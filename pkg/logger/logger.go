@@ -0,0 +1,37 @@
+// Package logger wraps log/slog with the JSON-by-default configuration
+// used across the service.
+package logger
+
+import (
+	"log/slog"
+	"os"
+
+	"github.com/KamillaKa/my-go-api/pkg/config"
+)
+
+// New builds a slog.Logger per cfg.Level/cfg.Format, writing to stdout.
+// Unrecognized formats fall back to JSON; unrecognized levels fall back to info.
+func New(cfg config.Log) *slog.Logger {
+	handlerOpts := &slog.HandlerOptions{Level: parseLevel(cfg.Level)}
+
+	var handler slog.Handler
+	if cfg.Format == "text" {
+		handler = slog.NewTextHandler(os.Stdout, handlerOpts)
+	} else {
+		handler = slog.NewJSONHandler(os.Stdout, handlerOpts)
+	}
+	return slog.New(handler)
+}
+
+func parseLevel(level string) slog.Level {
+	switch level {
+	case "debug":
+		return slog.LevelDebug
+	case "warn":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
@@ -0,0 +1,124 @@
+// Package search mirrors article writes into Elasticsearch and serves
+// full-text queries ranked by relevance instead of the MongoDB $regex scan.
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+
+	"github.com/olivere/elastic/v7"
+)
+
+const indexName = "articles"
+
+// Client wraps an Elasticsearch client. A nil *Client means search is
+// disabled and callers should fall back to the Mongo regex path.
+type Client struct {
+	es *elastic.Client
+}
+
+// Document is the shape indexed for each article.
+type Document struct {
+	ID      string `json:"id"`
+	Title   string `json:"title"`
+	Desc    string `json:"desc"`
+	Content string `json:"content"`
+}
+
+// Result is a single ranked hit returned to API clients.
+type Result struct {
+	Document
+	Score float64 `json:"score"`
+}
+
+// Response is the payload returned by the search handler.
+type Response struct {
+	Hits       []Result            `json:"hits"`
+	Total      int64               `json:"total"`
+	TookMs     int64               `json:"took_ms"`
+	Highlights map[string][]string `json:"highlights,omitempty"`
+}
+
+// mapping gives title/desc/content analyzed text fields with a keyword
+// sub-field for sorting and aggregations.
+const mapping = `{
+	"mappings": {
+		"properties": {
+			"title":   {"type": "text", "fields": {"keyword": {"type": "keyword"}}},
+			"desc":    {"type": "text", "fields": {"keyword": {"type": "keyword"}}},
+			"content": {"type": "text", "fields": {"keyword": {"type": "keyword"}}}
+		}
+	}
+}`
+
+// New connects to ES_URL and ensures the articles index exists. It returns a
+// nil *Client (and nil error) when ES_URL is unset, so the caller degrades
+// to Mongo regex search instead of failing startup.
+func New(ctx context.Context) (*Client, error) {
+	url := os.Getenv("ES_URL")
+	if url == "" {
+		return nil, nil
+	}
+
+	es, err := elastic.NewClient(elastic.SetURL(url), elastic.SetSniff(false))
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := es.IndexExists(indexName).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		if _, err := es.CreateIndex(indexName).BodyString(mapping).Do(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Client{es: es}, nil
+}
+
+// Index upserts a single article document under its Mongo _id.
+func (c *Client) Index(ctx context.Context, doc Document) error {
+	_, err := c.es.Index().Index(indexName).Id(doc.ID).BodyJson(doc).Do(ctx)
+	return err
+}
+
+// Search runs a multi_match query across title/desc/content with
+// highlighting on content, paginated via from/size.
+func (c *Client) Search(ctx context.Context, q string, from, size int) (*Response, error) {
+	query := elastic.NewMultiMatchQuery(q, "title", "desc", "content").Type("best_fields")
+	highlight := elastic.NewHighlight().Field("content")
+
+	result, err := c.es.Search().
+		Index(indexName).
+		Query(query).
+		Highlight(highlight).
+		From(from).Size(size).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &Response{
+		Total:      result.TotalHits(),
+		TookMs:     result.TookInMillis,
+		Highlights: map[string][]string{},
+	}
+	for _, hit := range result.Hits.Hits {
+		var doc Document
+		if err := json.Unmarshal(hit.Source, &doc); err != nil {
+			continue
+		}
+		var score float64
+		if hit.Score != nil {
+			score = *hit.Score
+		}
+		resp.Hits = append(resp.Hits, Result{Document: doc, Score: score})
+		if fragments, ok := hit.Highlight["content"]; ok {
+			resp.Highlights[doc.ID] = fragments
+		}
+	}
+	return resp, nil
+}
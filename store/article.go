@@ -0,0 +1,174 @@
+// Package store holds the article persistence logic shared by the REST
+// handlers in main and the GraphQL resolvers in graph, so both transports
+// go through the same Mongo code paths.
+package store
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/KamillaKa/my-go-api/attachments"
+)
+
+// Article is the canonical article record shared across transports.
+type Article struct {
+	ID          string                   `json:"_id,omitempty" bson:"_id,omitempty"`
+	Title       string                   `json:"Title" bson:"title"`
+	Desc        string                   `json:"desc" bson:"desc"`
+	Content     string                   `json:"content" bson:"content"`
+	AuthorID    string                   `json:"author_id,omitempty" bson:"author_id,omitempty"`
+	Attachments []attachments.Attachment `json:"attachments,omitempty" bson:"attachments,omitempty"`
+}
+
+// ListParams mirrors the filtering, sorting, and pagination query
+// parameters accepted by GET /articles.
+type ListParams struct {
+	Title     string
+	Desc      string
+	SortField string
+	SortOrder int
+	Page      int
+	Limit     int
+}
+
+// Articles wraps the articles collection with the CRUD operations shared by
+// the REST and GraphQL layers.
+type Articles struct {
+	collection *mongo.Collection
+}
+
+// NewArticles returns an Articles store backed by the given client.
+func NewArticles(client *mongo.Client) *Articles {
+	return &Articles{collection: client.Database("articles").Collection("go")}
+}
+
+// List applies filtering, sorting, and pagination and returns matching articles.
+func (a *Articles) List(ctx context.Context, p ListParams) ([]Article, error) {
+	filter := bson.M{}
+	if p.Title != "" {
+		filter["title"] = bson.M{"$regex": p.Title, "$options": "i"}
+	}
+	if p.Desc != "" {
+		filter["desc"] = bson.M{"$regex": p.Desc, "$options": "i"}
+	}
+
+	page, limit := p.Page, p.Limit
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = 10
+	}
+
+	sortOptions := options.Find()
+	if p.SortField != "" {
+		order := p.SortOrder
+		if order != 1 && order != -1 {
+			order = 1
+		}
+		sortOptions.SetSort(bson.D{{p.SortField, order}})
+	}
+	sortOptions.SetSkip(int64((page - 1) * limit))
+	sortOptions.SetLimit(int64(limit))
+
+	cursor, err := a.collection.Find(ctx, filter, sortOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var articles []Article
+	for cursor.Next(ctx) {
+		var article Article
+		if err := cursor.Decode(&article); err != nil {
+			return nil, err
+		}
+		articles = append(articles, article)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+	return articles, nil
+}
+
+// Get fetches a single article by id.
+func (a *Articles) Get(ctx context.Context, id string) (*Article, error) {
+	var article Article
+	if err := a.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&article); err != nil {
+		return nil, err
+	}
+	return &article, nil
+}
+
+// GetMany fetches several articles by id in a single query, used by the
+// GraphQL attachments DataLoader to avoid N+1 lookups.
+func (a *Articles) GetMany(ctx context.Context, ids []string) ([]Article, error) {
+	cursor, err := a.collection.Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var articles []Article
+	for cursor.Next(ctx) {
+		var article Article
+		if err := cursor.Decode(&article); err != nil {
+			return nil, err
+		}
+		articles = append(articles, article)
+	}
+	return articles, cursor.Err()
+}
+
+// Create inserts a new article, stamping its generated id.
+func (a *Articles) Create(ctx context.Context, article Article) (*Article, error) {
+	res, err := a.collection.InsertOne(ctx, article)
+	if err != nil {
+		return nil, err
+	}
+	article.ID = idString(res.InsertedID)
+	return &article, nil
+}
+
+// Update applies a partial update to an existing article and returns the
+// updated document.
+func (a *Articles) Update(ctx context.Context, id string, update Article) (*Article, error) {
+	set := bson.M{}
+	if update.Title != "" {
+		set["title"] = update.Title
+	}
+	if update.Desc != "" {
+		set["desc"] = update.Desc
+	}
+	if update.Content != "" {
+		set["content"] = update.Content
+	}
+
+	_, err := a.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": set})
+	if err != nil {
+		return nil, err
+	}
+	return a.Get(ctx, id)
+}
+
+// Delete removes an article by id.
+func (a *Articles) Delete(ctx context.Context, id string) error {
+	_, err := a.collection.DeleteOne(ctx, bson.M{"_id": id})
+	return err
+}
+
+func idString(id interface{}) string {
+	if oid, ok := id.(primitive.ObjectID); ok {
+		return oid.Hex()
+	}
+	if s, ok := id.(string); ok {
+		return s
+	}
+	return strconv.FormatInt(time.Now().UnixNano(), 10)
+}
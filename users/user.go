@@ -0,0 +1,93 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ErrInvalidCredentials is returned by Create when email or password is empty.
+var ErrInvalidCredentials = errors.New("email and password are required")
+
+// ErrEmailTaken is returned by Create when email is already registered.
+var ErrEmailTaken = errors.New("email is already registered")
+
+// User represents an account that can authenticate and author articles.
+type User struct {
+	ID           string `json:"_id,omitempty" bson:"_id,omitempty"`
+	Email        string `json:"email" bson:"email"`
+	PasswordHash string `json:"-" bson:"password_hash"`
+}
+
+// Collection returns the users collection on the articles database.
+func Collection(client *mongo.Client) *mongo.Collection {
+	return client.Database("articles").Collection("users")
+}
+
+// Create hashes the given password and inserts a new user document. It
+// rejects empty credentials and re-registration of an email that's already
+// taken; there's no unique index backing this, so the check is a
+// FindByEmail pre-check rather than a duplicate-key error.
+func Create(ctx context.Context, collection *mongo.Collection, email, password string) (*User, error) {
+	if email == "" || password == "" {
+		return nil, ErrInvalidCredentials
+	}
+
+	if _, err := FindByEmail(ctx, collection, email); err == nil {
+		return nil, ErrEmailTaken
+	} else if !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	user := User{Email: email, PasswordHash: string(hash)}
+	res, err := collection.InsertOne(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	user.ID = idString(res.InsertedID)
+	return &user, nil
+}
+
+// idString normalizes a Mongo InsertedID into its string form. Mongo
+// auto-assigns a primitive.ObjectID when _id isn't set on insert, which is
+// the case here since User never sets ID before InsertOne.
+func idString(id interface{}) string {
+	if oid, ok := id.(primitive.ObjectID); ok {
+		return oid.Hex()
+	}
+	if s, ok := id.(string); ok {
+		return s
+	}
+	return fmt.Sprintf("%v", id)
+}
+
+// FindByEmail looks up a user by email, returning mongo.ErrNoDocuments if absent.
+func FindByEmail(ctx context.Context, collection *mongo.Collection, email string) (*User, error) {
+	var user User
+	err := collection.FindOne(ctx, bson.M{"email": email}).Decode(&user)
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// CheckPassword reports whether password matches the stored hash.
+func (u *User) CheckPassword(password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password)) == nil
+}
+
+// WithTimeout is a small helper mirroring the timeout pattern used by the article handlers.
+func WithTimeout() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), 10*time.Second)
+}
@@ -0,0 +1,124 @@
+// Package ws implements a WebSocket hub that streams newly created articles
+// to connected clients in real time, as an alternative to polling /articles.
+package ws
+
+import (
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// pingInterval is how often the server pings a client to keep the
+	// connection alive.
+	pingInterval = 54 * time.Second
+	// readDeadline is how long the server waits for a pong before giving up
+	// on a connection.
+	readDeadline = 60 * time.Second
+	// writeWait is how long a single write may take.
+	writeWait = 10 * time.Second
+	sendBufferSize = 256
+)
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin:     func(r *http.Request) bool { return true },
+}
+
+// Client is a single connected WebSocket subscriber.
+type Client struct {
+	hub    *Hub
+	conn   *websocket.Conn
+	send   chan []byte
+	title  string // optional server-side filter on article title
+}
+
+// Hub tracks connected clients and broadcasts article events to them. All
+// membership changes and broadcasts flow through register/unregister/
+// broadcast channels handled by a single goroutine (Run), so no locking is
+// needed around the client map.
+type Hub struct {
+	clients    map[*Client]bool
+	register   chan *Client
+	unregister chan *Client
+	broadcast  chan event
+}
+
+// event pairs the article's title with its JSON payload so the hub can
+// apply each client's server-side ?title= filter before delivering it.
+type event struct {
+	title   string
+	payload []byte
+}
+
+// NewHub creates a Hub. Call Run in its own goroutine to start it.
+func NewHub() *Hub {
+	return &Hub{
+		clients:    make(map[*Client]bool),
+		register:   make(chan *Client),
+		unregister: make(chan *Client),
+		broadcast:  make(chan event),
+	}
+}
+
+// Run processes registrations, unregistrations, and broadcasts until the
+// program exits. It should be started with `go hub.Run()`.
+func (h *Hub) Run() {
+	for {
+		select {
+		case client := <-h.register:
+			h.clients[client] = true
+
+		case client := <-h.unregister:
+			if _, ok := h.clients[client]; ok {
+				delete(h.clients, client)
+				close(client.send)
+			}
+
+		case ev := <-h.broadcast:
+			for client := range h.clients {
+				if client.title != "" && !strings.Contains(strings.ToLower(ev.title), strings.ToLower(client.title)) {
+					continue
+				}
+				select {
+				case client.send <- ev.payload:
+				default:
+					delete(h.clients, client)
+					close(client.send)
+				}
+			}
+		}
+	}
+}
+
+// Publish queues an article event for delivery to clients whose ?title=
+// filter matches (or who have no filter set).
+func (h *Hub) Publish(title string, payload []byte) {
+	h.broadcast <- event{title: title, payload: payload}
+}
+
+// ServeWS upgrades the request to a WebSocket connection and registers a new
+// client, optionally filtering the feed to articles whose title contains
+// the ?title= query parameter.
+func (h *Hub) ServeWS(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Printf("ws: upgrade failed: %v", err)
+		return
+	}
+
+	client := &Client{
+		hub:   h,
+		conn:  conn,
+		send:  make(chan []byte, sendBufferSize),
+		title: r.URL.Query().Get("title"),
+	}
+	client.hub.register <- client
+
+	go client.writePump()
+	go client.readPump()
+}